@@ -2,7 +2,9 @@ package main
 
 import (
 	"errors"
+	"iter"
 	"math"
+	"math/big"
 )
 
 var (
@@ -12,24 +14,94 @@ var (
 
 // Fibonacci 함수는 n번째 피보나치 수를 계산합니다.
 // n이 음수이거나 결과가 uint64를 초과하면 에러를 반환합니다.
+// 내부적으로는 FibonacciSeq를 끝까지 소비하는 얇은 래퍼입니다.
 func Fibonacci(n int) (uint64, error) {
 	if n < 0 {
 		return 0, ErrNegativeInput
 	}
-	if n <= 1 {
-		return uint64(n), nil
+
+	var result uint64
+	reached := false
+	for i, v := range FibonacciSeq(n) {
+		if i == n {
+			result, reached = v, true
+		}
+	}
+	if !reached {
+		return 0, ErrUint64Overflow
 	}
+	return result, nil
+}
 
-	var prev, current uint64 = 0, 1
-	for i := 2; i <= n; i++ {
-		// 오버플로우 체크
-		next, overflow := addUint64(prev, current)
-		if overflow {
-			return 0, ErrUint64Overflow
+// FibonacciSeq는 F(0)부터 F(n)까지의 (인덱스, 값) 쌍을 순서대로 yield하는
+// iter.Seq2를 반환합니다. 값이 uint64 범위를 초과하면 그 전에 순회를 멈춥니다.
+func FibonacciSeq(n int) iter.Seq2[int, uint64] {
+	return func(yield func(int, uint64) bool) {
+		if n < 0 {
+			return
+		}
+		if !yield(0, 0) {
+			return
+		}
+		if n == 0 {
+			return
 		}
-		prev, current = current, next
+
+		var prev, current uint64 = 0, 1
+		if !yield(1, current) {
+			return
+		}
+
+		for i := 2; i <= n; i++ {
+			// 오버플로우 체크
+			next, overflow := addUint64(prev, current)
+			if overflow {
+				return
+			}
+			prev, current = current, next
+			if !yield(i, current) {
+				return
+			}
+		}
+	}
+}
+
+// FibonacciBig은 fast doubling을 사용해 O(log n)번의 big.Int 곱셈만으로
+// n번째 피보나치 수를 계산합니다. n이 음수이면 에러를 반환합니다.
+//
+//	F(2k)   = F(k) * (2*F(k+1) - F(k))
+//	F(2k+1) = F(k)^2 + F(k+1)^2
+func FibonacciBig(n int) (*big.Int, error) {
+	if n < 0 {
+		return nil, ErrNegativeInput
+	}
+	fn, _ := fibDoubling(n)
+	return fn, nil
+}
+
+// fibDoubling은 fast doubling 재귀식을 이용해 (F(k), F(k+1)) 쌍을 반환합니다.
+func fibDoubling(k int) (*big.Int, *big.Int) {
+	if k == 0 {
+		return big.NewInt(0), big.NewInt(1)
+	}
+
+	half, half1 := fibDoubling(k / 2)
+
+	// F(2m) = F(m) * (2*F(m+1) - F(m))
+	t := new(big.Int).Lsh(half1, 1)
+	t.Sub(t, half)
+	f2m := new(big.Int).Mul(half, t)
+
+	// F(2m+1) = F(m)^2 + F(m+1)^2
+	f2m1 := new(big.Int).Add(
+		new(big.Int).Mul(half, half),
+		new(big.Int).Mul(half1, half1),
+	)
+
+	if k%2 == 0 {
+		return f2m, f2m1
 	}
-	return current, nil
+	return f2m1, new(big.Int).Add(f2m, f2m1)
 }
 
 // addUint64는 두 uint64 값을 더하고 오버플로우 여부를 반환합니다.
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"math/big"
 	"testing"
 )
 
@@ -41,3 +42,118 @@ func TestFibonacci(t *testing.T) {
 		}
 	})
 }
+
+func TestFibonacciBig(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   int
+		want    string
+		wantErr error
+	}{
+		{"0번째 수", 0, "0", nil},
+		{"1번째 수", 1, "1", nil},
+		{"2번째 수", 2, "1", nil},
+		{"10번째 수", 10, "55", nil},
+		{"94번째 수 (uint64 범위 초과)", 94, "19740274219868223167", nil},
+		{"음수 입력", -1, "", ErrNegativeInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FibonacciBig(tt.input)
+			if err != tt.wantErr {
+				t.Errorf("FibonacciBig(%d) 에러 = %v, 원하는 에러 = %v", tt.input, err, tt.wantErr)
+				return
+			}
+			if err == nil {
+				want, _ := new(big.Int).SetString(tt.want, 10)
+				if got.Cmp(want) != 0 {
+					t.Errorf("FibonacciBig(%d) = %v, 원하는 값 = %v", tt.input, got, want)
+				}
+			}
+		})
+	}
+
+	t.Run("linear 구현과 n=1000에서 일치", func(t *testing.T) {
+		got, err := FibonacciBig(1000)
+		if err != nil {
+			t.Fatalf("FibonacciBig(1000) 에러 = %v", err)
+		}
+		if want := fibonacciLinearBig(1000); got.Cmp(want) != 0 {
+			t.Errorf("FibonacciBig(1000) = %v, 원하는 값 = %v", got, want)
+		}
+	})
+}
+
+func TestFibonacciSeq(t *testing.T) {
+	var got []uint64
+	for _, v := range FibonacciSeq(10) {
+		got = append(got, v)
+	}
+	want := []uint64{0, 1, 1, 2, 3, 5, 8, 13, 21, 34, 55}
+	if len(got) != len(want) {
+		t.Fatalf("FibonacciSeq(10) 길이 = %d, 원하는 길이 = %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("FibonacciSeq(10) 값[%d] = %v, 원하는 값 = %v", i, got[i], want[i])
+		}
+	}
+
+	t.Run("오버플로우 이전에 멈춤", func(t *testing.T) {
+		count := 0
+		for range FibonacciSeq(1000) {
+			count++
+		}
+		if count != 94 { // F(0)..F(93)까지만 uint64 범위 안에 들어옵니다
+			t.Errorf("FibonacciSeq(1000) 개수 = %d, 원하는 개수 = 94", count)
+		}
+	})
+
+	t.Run("early break로 조기 종료", func(t *testing.T) {
+		count := 0
+		for range FibonacciSeq(10) {
+			count++
+			if count == 3 {
+				break
+			}
+		}
+		if count != 3 {
+			t.Errorf("early break 이후 개수 = %d, 원하는 개수 = 3", count)
+		}
+	})
+}
+
+// fibonacciLinearBig은 빠른 배증법과 비교하기 위한 단순 O(n) 반복 구현입니다.
+func fibonacciLinearBig(n int) *big.Int {
+	prev, current := big.NewInt(0), big.NewInt(1)
+	for i := 2; i <= n; i++ {
+		prev, current = current, new(big.Int).Add(prev, current)
+	}
+	if n == 0 {
+		return big.NewInt(0)
+	}
+	return current
+}
+
+func benchmarkFibonacciLinearBig(n int, b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		fibonacciLinearBig(n)
+	}
+}
+
+func benchmarkFibonacciBig(n int, b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := FibonacciBig(n); err != nil {
+			b.Fatalf("FibonacciBig(%d) 에러 = %v", n, err)
+		}
+	}
+}
+
+func BenchmarkFibonacciLinearBig1000(b *testing.B)   { benchmarkFibonacciLinearBig(1000, b) }
+func BenchmarkFibonacciLinearBig10000(b *testing.B)  { benchmarkFibonacciLinearBig(10000, b) }
+func BenchmarkFibonacciLinearBig100000(b *testing.B) { benchmarkFibonacciLinearBig(100000, b) }
+
+func BenchmarkFibonacciBig1000(b *testing.B)   { benchmarkFibonacciBig(1000, b) }
+func BenchmarkFibonacciBig10000(b *testing.B)  { benchmarkFibonacciBig(10000, b) }
+func BenchmarkFibonacciBig100000(b *testing.B) { benchmarkFibonacciBig(100000, b) }
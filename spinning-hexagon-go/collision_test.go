@@ -0,0 +1,68 @@
+package main
+
+import "testing"
+
+// TestCollideBallsHeadOn exercises the elastic-impulse centerpiece of the
+// ball-ball collision request: two equal-mass balls approaching head-on
+// should exchange velocities (up to restitution) and separate along the
+// collision normal.
+func TestCollideBallsHeadOn(t *testing.T) {
+	g := &Game{Balls: []Ball{
+		{Pos: Vector{X: 0, Y: 0}, Vel: Vector{X: 100, Y: 0}, Radius: ballRadius, Mass: defaultBallMass},
+		{Pos: Vector{X: ballRadius*2 - 1, Y: 0}, Vel: Vector{X: -100, Y: 0}, Radius: ballRadius, Mass: defaultBallMass},
+	}}
+
+	g.collideBalls(0, 1)
+
+	a, b := g.Balls[0], g.Balls[1]
+	if a.Vel.X >= 0 {
+		t.Errorf("ball 0 Vel.X = %v, want negative (bounced back)", a.Vel.X)
+	}
+	if b.Vel.X <= 0 {
+		t.Errorf("ball 1 Vel.X = %v, want positive (bounced back)", b.Vel.X)
+	}
+	if dist := b.Pos.Sub(a.Pos).Length(); dist < a.Radius+b.Radius {
+		t.Errorf("post-resolution distance = %v, want >= %v (no longer overlapping)", dist, a.Radius+b.Radius)
+	}
+}
+
+// TestCollideBallsSeparating checks that balls already moving apart are left
+// alone, even while still geometrically overlapping.
+func TestCollideBallsSeparating(t *testing.T) {
+	g := &Game{Balls: []Ball{
+		{Pos: Vector{X: 0, Y: 0}, Vel: Vector{X: -50, Y: 0}, Radius: ballRadius, Mass: defaultBallMass},
+		{Pos: Vector{X: ballRadius, Y: 0}, Vel: Vector{X: 50, Y: 0}, Radius: ballRadius, Mass: defaultBallMass},
+	}}
+
+	g.collideBalls(0, 1)
+
+	if g.Balls[0].Vel.X != -50 || g.Balls[1].Vel.X != 50 {
+		t.Errorf("velocities changed for a separating pair: %+v, %+v", g.Balls[0].Vel, g.Balls[1].Vel)
+	}
+}
+
+// TestGridBroadphasePairsOversizedBall guards against the grid broadphase
+// silently dropping overlapping pairs when a ball's radius (set via the
+// AddBall API, which allows any r) exceeds gridCellSize: cells must widen to
+// fit the largest ball in play rather than staying fixed at the constant.
+func TestGridBroadphasePairsOversizedBall(t *testing.T) {
+	g := &Game{UseSpatialGrid: true}
+	// Big enough that the pair overlaps (dist < r1+r2) while still landing
+	// more than one gridCellSize-wide cell apart under the old fixed bucket
+	// size, so a naive 3x3 neighborhood scan would miss it.
+	bigRadius := 5 * gridCellSize
+	g.AddBall(Vector{X: 0, Y: 0}, Vector{}, bigRadius)
+	g.AddBall(Vector{X: 7.5 * gridCellSize, Y: 0}, Vector{}, bigRadius)
+
+	pairs := g.gridBroadphasePairs()
+
+	found := false
+	for _, p := range pairs {
+		if p == [2]int{0, 1} {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("gridBroadphasePairs() = %v, want it to include the overlapping oversized pair {0,1}", pairs)
+	}
+}
@@ -5,7 +5,7 @@ import (
 	"math"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
 // --- Simulation constants ---
@@ -21,6 +21,19 @@ const (
 	hexagonRadius       = 200.0
 	initialHexagonAngle = 0.0
 	hexagonAngularVel   = 1.0 // radian/s (counterclockwise rotation)
+
+	defaultBallMass = 1.0
+	gridCellSize    = 4 * ballRadius // uniform-grid broadphase bucket size
+
+	angularVelocityStep = 0.5   // radians/s added per frame the arrow key is held
+	gravityStep         = 10.0  // pixels/s^2 added per frame +/- is held
+	launchSpeed         = 200.0 // initial speed given to a ball on SpacePressed
+
+	defaultPhysicsHz        = 240.0 // substep rate; 4 substeps per 60Hz frame
+	maxCollisionsPerSubstep = 4     // corner-trap guard for the CCD loop
+	tunnelSpeedFactor       = 0.5   // below relSpeed*h < this*radius, skip CCD
+
+	trailLength = 20 // number of past positions kept per ball for the trail render
 )
 
 // --- Basic vector math ---
@@ -47,70 +60,416 @@ type Ball struct {
 	Pos    Vector // position in pixels
 	Vel    Vector // velocity in pixels/second
 	Radius float64
+	Mass   float64 // used for ball-ball impulse resolution; set via AddBall
+	Trail  trail   // ring buffer of recent positions, for the fading trail render
+}
+
+// --- Motion trail ---
+
+// trail is a fixed-capacity ring buffer of a ball's most recent positions,
+// sampled once per frame, used to render a fading motion trail. Its zero
+// value is an empty, ready-to-use buffer.
+type trail struct {
+	positions [trailLength]Vector
+	count     int // number of valid entries, capped at trailLength
+	head      int // ring index the next push() writes to
+}
+
+// push records the ball's current position, evicting the oldest one once
+// the buffer is full.
+func (t *trail) push(p Vector) {
+	t.positions[t.head] = p
+	t.head = (t.head + 1) % trailLength
+	if t.count < trailLength {
+		t.count++
+	}
 }
 
-// --- Hexagon definition ---
-// The hexagon is defined by its center, the distance from its center to each vertex,
-// its current rotation angle, and its constant angular velocity.
-type Hexagon struct {
+// at returns the i-th most recent position (0 = newest), or ok=false if
+// fewer than i+1 positions have been recorded yet.
+func (t *trail) at(i int) (pos Vector, ok bool) {
+	if i >= t.count {
+		return Vector{}, false
+	}
+	idx := (t.head - 1 - i + trailLength) % trailLength
+	return t.positions[idx], true
+}
+
+// --- Polygon definition ---
+// Polygon generalizes the original hard-coded hexagon to an arbitrary N-gon
+// container, convex or concave. LocalVertices are stored in the polygon's
+// own local space, unrotated and centered on the origin; Vertices() rotates
+// and translates them into world space each frame.
+type Polygon struct {
 	Center          Vector
-	Radius          float64 // distance from center to vertex
-	Angle           float64 // current rotation angle (radians)
-	AngularVelocity float64 // in radians/second
-}
-
-// Vertices returns the six vertices of the hexagon based on its current angle.
-func (h *Hexagon) Vertices() []Vector {
-	vertices := make([]Vector, 6)
-	for i := 0; i < 6; i++ {
-		angle := h.Angle + float64(i)*math.Pi/3.0 // 60° intervals
-		vertices[i] = Vector{
-			X: h.Center.X + h.Radius*math.Cos(angle),
-			Y: h.Center.Y + h.Radius*math.Sin(angle),
+	LocalVertices   []Vector // local-space vertices, unrotated, origin-centered
+	Angle           float64  // current rotation angle (radians)
+	AngularVelocity float64  // in radians/second
+}
+
+// NewRegularPolygon builds a regular N-sided polygon of the given
+// circumradius, replacing the old hard-coded 6-vertex hexagon.
+func NewRegularPolygon(n int, radius float64) *Polygon {
+	verts := make([]Vector, n)
+	for i := 0; i < n; i++ {
+		angle := float64(i) * 2 * math.Pi / float64(n)
+		verts[i] = Vector{X: radius * math.Cos(angle), Y: radius * math.Sin(angle)}
+	}
+	return NewPolygonFromVertices(verts)
+}
+
+// NewPolygonFromVertices builds a polygon from arbitrary local-space
+// vertices (CCW winding).
+func NewPolygonFromVertices(verts []Vector) *Polygon {
+	return &Polygon{LocalVertices: verts}
+}
+
+// IsConvex reports whether the polygon's local vertices form a convex loop.
+func (p *Polygon) IsConvex() bool {
+	return isConvex(p.LocalVertices)
+}
+
+// Vertices returns the polygon's vertices in world space: LocalVertices
+// rotated by Angle and translated to Center.
+func (p *Polygon) Vertices() []Vector {
+	world := make([]Vector, len(p.LocalVertices))
+	cos, sin := math.Cos(p.Angle), math.Sin(p.Angle)
+	for i, v := range p.LocalVertices {
+		world[i] = Vector{
+			X: p.Center.X + v.X*cos - v.Y*sin,
+			Y: p.Center.Y + v.X*sin + v.Y*cos,
+		}
+	}
+	return world
+}
+
+// isConvex reports whether verts turns consistently in one rotational
+// direction at every vertex, i.e. has no reflex corners.
+func isConvex(verts []Vector) bool {
+	n := len(verts)
+	if n < 3 {
+		return false
+	}
+	gotPositive, gotNegative := false, false
+	for i := 0; i < n; i++ {
+		a, b, c := verts[i], verts[(i+1)%n], verts[(i+2)%n]
+		switch cross := b.Sub(a).X*c.Sub(b).Y - b.Sub(a).Y*c.Sub(b).X; {
+		case cross > 0:
+			gotPositive = true
+		case cross < 0:
+			gotNegative = true
 		}
+		if gotPositive && gotNegative {
+			return false
+		}
+	}
+	return true
+}
+
+// --- Input handling ---
+
+// Input abstracts the keys that drive the simulation so Game.Update can be
+// driven without a real keyboard, e.g. by RecordedInput in tests.
+type Input interface {
+	LeftPressed() bool
+	RightPressed() bool
+	SpacePressed() bool
+	PlusPressed() bool
+	MinusPressed() bool
+}
+
+// EbitenInput is the default Input backed by the real keyboard.
+type EbitenInput struct{}
+
+func (EbitenInput) LeftPressed() bool  { return ebiten.IsKeyPressed(ebiten.KeyLeft) }
+func (EbitenInput) RightPressed() bool { return ebiten.IsKeyPressed(ebiten.KeyRight) }
+func (EbitenInput) SpacePressed() bool { return ebiten.IsKeyPressed(ebiten.KeySpace) }
+func (EbitenInput) PlusPressed() bool {
+	return ebiten.IsKeyPressed(ebiten.KeyEqual) || ebiten.IsKeyPressed(ebiten.KeyKPAdd)
+}
+func (EbitenInput) MinusPressed() bool {
+	return ebiten.IsKeyPressed(ebiten.KeyMinus) || ebiten.IsKeyPressed(ebiten.KeyKPSubtract)
+}
+
+// InputFrame is one frame's worth of recorded key state.
+type InputFrame struct {
+	Left, Right, Space, Plus, Minus bool
+}
+
+// RecordedInput replays a fixed []InputFrame, one frame per Update call, so
+// physics regression tests can drive the simulation deterministically.
+// Once the frames are exhausted it reports no keys pressed.
+type RecordedInput struct {
+	Frames []InputFrame
+	frame  int
+}
+
+func (r *RecordedInput) current() InputFrame {
+	if r.frame >= len(r.Frames) {
+		return InputFrame{}
 	}
-	return vertices
+	return r.Frames[r.frame]
+}
+
+func (r *RecordedInput) LeftPressed() bool  { return r.current().Left }
+func (r *RecordedInput) RightPressed() bool { return r.current().Right }
+func (r *RecordedInput) SpacePressed() bool { return r.current().Space }
+func (r *RecordedInput) PlusPressed() bool  { return r.current().Plus }
+func (r *RecordedInput) MinusPressed() bool { return r.current().Minus }
+
+// Advance moves to the next recorded frame. Game.Update calls it once per
+// frame after sampling input.
+func (r *RecordedInput) Advance() {
+	r.frame++
 }
 
 // --- Game definition ---
 type Game struct {
-	ball Ball
-	hex  Hexagon
+	Balls []Ball
+	hex   Polygon
+
+	// Input is sampled once per Update to drive hex rotation, the
+	// ball launch/reset, and gravity. Defaults to EbitenInput in main;
+	// tests can swap in a RecordedInput instead.
+	Input Input
+
+	// Gravity is mutable (unlike the gravity constant) so it can be
+	// adjusted at runtime via Input.PlusPressed/MinusPressed.
+	Gravity float64
+
+	// PhysicsHz is the fixed physics update rate; each 60Hz frame runs
+	// PhysicsHz/60 substeps so results stay reproducible independent of
+	// the display framerate. Defaults to defaultPhysicsHz when <= 0.
+	PhysicsHz float64
+
+	// UseSpatialGrid switches the ball-ball broadphase from the default
+	// O(n²) all-pairs scan to a uniform grid. Flip it on when stress-testing
+	// hundreds of balls; for small counts the all-pairs scan is cheaper.
+	UseSpatialGrid bool
+}
+
+// AddBall spawns a new ball into the simulation and returns its index in
+// g.Balls.
+func (g *Game) AddBall(pos, vel Vector, r float64) int {
+	g.Balls = append(g.Balls, Ball{Pos: pos, Vel: vel, Radius: r, Mass: defaultBallMass})
+	return len(g.Balls) - 1
+}
+
+// inputAdvancer is implemented by Input sources that need to move to the
+// next frame after being sampled, such as RecordedInput.
+type inputAdvancer interface {
+	Advance()
+}
+
+// handleInput samples g.Input and applies arrow-key hexagon rotation,
+// +/- gravity adjustment, and a space-bar launch/reset of the first ball.
+func (g *Game) handleInput() {
+	if g.Input == nil {
+		return
+	}
+
+	if g.Input.LeftPressed() {
+		g.hex.AngularVelocity -= angularVelocityStep * dt
+	}
+	if g.Input.RightPressed() {
+		g.hex.AngularVelocity += angularVelocityStep * dt
+	}
+	if g.Input.PlusPressed() {
+		g.Gravity += gravityStep * dt
+	}
+	if g.Input.MinusPressed() {
+		g.Gravity -= gravityStep * dt
+	}
+	if g.Input.SpacePressed() {
+		g.launchFirstBall()
+	}
+
+	if adv, ok := g.Input.(inputAdvancer); ok {
+		adv.Advance()
+	}
+}
+
+// launchFirstBall resets the first ball to the hexagon's center and gives
+// it a fixed launch velocity. If there are no balls yet, it spawns one.
+func (g *Game) launchFirstBall() {
+	if len(g.Balls) == 0 {
+		g.AddBall(g.hex.Center, Vector{X: launchSpeed, Y: -launchSpeed}, ballRadius)
+		return
+	}
+	g.Balls[0].Pos = g.hex.Center
+	g.Balls[0].Vel = Vector{X: launchSpeed, Y: -launchSpeed}
 }
 
-// Update is called every frame (60 times per second).
+// Update is called every frame (60 times per second). It runs the physics
+// at a fixed rate of g.PhysicsHz by subdividing the frame into equal
+// substeps, so results are reproducible independent of the display
+// framerate and independent of how many substeps that rate works out to.
 func (g *Game) Update() error {
-	// --- Update ball physics ---
-	// 1. Apply gravity (accelerate downward).
-	g.ball.Vel.Y += gravity * dt
+	g.handleInput()
 
-	// 2. Update ball position.
-	g.ball.Pos = g.ball.Pos.Add(g.ball.Vel.Mul(dt))
+	substeps := g.physicsSubsteps()
+	h := dt / float64(substeps)
+	for s := 0; s < substeps; s++ {
+		g.substep(h)
+	}
 
-	// 3. Apply air friction (damping).
-	g.ball.Vel = g.ball.Vel.Mul(airFriction)
+	// --- (Optional) Fallback boundary: prevent balls from falling off-screen ---
+	for i := range g.Balls {
+		b := &g.Balls[i]
+		if b.Pos.Y > screenHeight-b.Radius {
+			b.Pos.Y = screenHeight - b.Radius
+			b.Vel.Y = -b.Vel.Y * restitution
+		}
+	}
 
-	// --- Update hexagon rotation ---
-	g.hex.Angle += g.hex.AngularVelocity * dt
+	// Sample each ball's position once per frame into its trail buffer.
+	for i := range g.Balls {
+		g.Balls[i].Trail.push(g.Balls[i].Pos)
+	}
+
+	return nil
+}
+
+// physicsSubsteps returns how many fixed-duration substeps make up one
+// 60Hz frame at g.PhysicsHz, defaulting to defaultPhysicsHz.
+func (g *Game) physicsSubsteps() int {
+	hz := g.PhysicsHz
+	if hz <= 0 {
+		hz = defaultPhysicsHz
+	}
+	n := int(hz * dt)
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// substep advances the whole simulation by a single fixed duration h using
+// semi-implicit Euler: velocities (gravity, hexagon rotation) are updated
+// first, then positions are integrated against those new velocities via
+// continuous collision detection, and finally ball-ball collisions are
+// resolved against the post-move positions.
+func (g *Game) substep(h float64) {
+	for i := range g.Balls {
+		g.Balls[i].Vel.Y += g.Gravity * h
+	}
+
+	g.hex.Angle += g.hex.AngularVelocity * h
 
-	// --- Collision detection and response ---
 	vertices := g.hex.Vertices()
-	// Process each of the 6 edges of the hexagon.
-	for i := 0; i < 6; i++ {
+	for i := range g.Balls {
+		g.integrateBall(&g.Balls[i], vertices, h)
+	}
+
+	// airFriction is calibrated per 60Hz frame, so scale its exponent by
+	// the substep's fraction of a frame to keep damping framerate-independent.
+	perSubstepFriction := math.Pow(airFriction, h/dt)
+	for i := range g.Balls {
+		g.Balls[i].Vel = g.Balls[i].Vel.Mul(perSubstepFriction)
+	}
+
+	g.resolveBallCollisions()
+}
+
+// integrateBall advances a single ball through a substep of duration h.
+// When the ball is moving slowly enough relative to its radius that it
+// can't tunnel through a wall this substep, it falls back to the simpler
+// discrete advance-then-push-out. Otherwise it repeatedly sweeps the ball
+// along its velocity, stopping at the earliest wall contact, applying the
+// impulse there, and continuing with the remaining time, up to
+// maxCollisionsPerSubstep times so a corner trap can't spin it forever.
+func (g *Game) integrateBall(b *Ball, vertices []Vector, h float64) {
+	if b.Vel.Length()*h < tunnelSpeedFactor*b.Radius {
+		b.Pos = b.Pos.Add(b.Vel.Mul(h))
+		g.collideBallWithPolygon(b, vertices)
+		return
+	}
+
+	remaining := h
+	for iter := 0; iter < maxCollisionsPerSubstep; iter++ {
+		tHit, normal, wallVel, hit := g.sweepBallAgainstPolygon(b, vertices, remaining)
+		if !hit {
+			b.Pos = b.Pos.Add(b.Vel.Mul(remaining))
+			return
+		}
+
+		b.Pos = b.Pos.Add(b.Vel.Mul(tHit))
+		remaining -= tHit
+
+		relVel := b.Vel.Sub(wallVel)
+		vn := relVel.Dot(normal)
+		if vn < 0 {
+			relVel = relVel.Sub(normal.Mul((1 + restitution) * vn))
+			tangent := relVel.Sub(normal.Mul(relVel.Dot(normal)))
+			tangent = tangent.Mul(1 - collisionFriction)
+			relVel = normal.Mul(relVel.Dot(normal)).Add(tangent)
+			b.Vel = wallVel.Add(relVel)
+		}
+
+		if remaining <= 0 {
+			return
+		}
+	}
+
+	// Hit the iteration cap (e.g. wedged into a corner): spend the rest of
+	// the substep with a plain advance and a discrete push-out rather than
+	// looping forever.
+	b.Pos = b.Pos.Add(b.Vel.Mul(remaining))
+	g.collideBallWithPolygon(b, vertices)
+}
+
+// sweepBallAgainstPolygon finds the earliest time t in [0, maxT] at which
+// ball b, moving in a straight line at its current velocity, first touches
+// any edge of g.hex, along with the contact normal and the wall's velocity
+// at the contact point (needed for the impulse response).
+func (g *Game) sweepBallAgainstPolygon(b *Ball, vertices []Vector, maxT float64) (t float64, normal, wallVel Vector, hit bool) {
+	bestT := maxT
+	n := len(vertices)
+	for i := 0; i < n; i++ {
+		a := vertices[i]
+		c := vertices[(i+1)%n]
+		if eh, ok := sweepCircleAgainstSegment(b.Pos, b.Vel, a, c, b.Radius, bestT); ok {
+			bestT = eh.t
+			normal = eh.normal
+			hit = true
+		}
+	}
+	if !hit {
+		return 0, Vector{}, Vector{}, false
+	}
+
+	contact := b.Pos.Add(b.Vel.Mul(bestT))
+	wallPoint := contact.Sub(normal.Mul(b.Radius))
+	r := wallPoint.Sub(g.hex.Center)
+	wallVel = Vector{
+		X: -g.hex.AngularVelocity * r.Y,
+		Y: g.hex.AngularVelocity * r.X,
+	}
+	return bestT, normal, wallVel, true
+}
+
+// collideBallWithPolygon resolves a collision between ball b and the
+// polygon's edges, given its current world-space vertices. It walls off
+// only the true boundary (vertex i to vertex i+1): for a concave polygon,
+// colliding against a triangulated decomposition's internal diagonals
+// would trap a ball inside whichever compartment it started in.
+func (g *Game) collideBallWithPolygon(b *Ball, vertices []Vector) {
+	n := len(vertices)
+	for i := 0; i < n; i++ {
 		a := vertices[i]
-		b := vertices[(i+1)%6]
-		// Compute the closest point on the edge [a,b] to the ball's center.
-		closest := closestPointOnSegment(a, b, g.ball.Pos)
+		c := vertices[(i+1)%n]
+		// Compute the closest point on the edge [a,c] to the ball's center.
+		closest := closestPointOnSegment(a, c, b.Pos)
 		// Vector from the closest point on the edge to the ball center.
-		diff := g.ball.Pos.Sub(closest)
+		diff := b.Pos.Sub(closest)
 		dist := diff.Length()
-		if dist < g.ball.Radius {
+		if dist < b.Radius {
 			// --- Collision detected ---
 
 			// Penetration depth (how far the ball is inside the wall).
-			penetration := g.ball.Radius - dist
+			penetration := b.Radius - dist
 			// Normal pointing from wall toward ball center.
-			n := diff.Normalize()
+			normal := diff.Normalize()
 
 			// --- Determine wall velocity at collision point ---
 			// The wall (hexagon) rotates about its center.
@@ -125,56 +484,180 @@ func (g *Game) Update() error {
 
 			// --- Collision response ---
 			// Compute the relative velocity between ball and wall.
-			relVel := g.ball.Vel.Sub(wallVel)
+			relVel := b.Vel.Sub(wallVel)
 			// Component along the collision normal.
-			vn := relVel.Dot(n)
+			vn := relVel.Dot(normal)
 			if vn < 0 {
 				// Reflect the relative velocity using the restitution coefficient.
 				// v' = v - (1+e)*(v · n)*n
-				relVel = relVel.Sub(n.Mul((1 + restitution) * vn))
+				relVel = relVel.Sub(normal.Mul((1 + restitution) * vn))
 
 				// Apply friction on the tangential (parallel) component.
 				// First extract the tangential component.
-				tangent := relVel.Sub(n.Mul(relVel.Dot(n)))
+				tangent := relVel.Sub(normal.Mul(relVel.Dot(normal)))
 				tangent = tangent.Mul(1 - collisionFriction)
 
 				// Reconstruct the new relative velocity.
-				relVel = n.Mul(relVel.Dot(n)).Add(tangent)
+				relVel = normal.Mul(relVel.Dot(normal)).Add(tangent)
 
 				// The new ball velocity is the wall velocity plus the corrected relative velocity.
-				g.ball.Vel = wallVel.Add(relVel)
+				b.Vel = wallVel.Add(relVel)
 			}
 
 			// Resolve penetration by pushing the ball out along the collision normal.
-			g.ball.Pos = g.ball.Pos.Add(n.Mul(penetration))
+			b.Pos = b.Pos.Add(normal.Mul(penetration))
 		}
 	}
+}
 
-	// --- (Optional) Fallback boundary: prevent the ball from falling off-screen ---
-	if g.ball.Pos.Y > screenHeight-g.ball.Radius {
-		g.ball.Pos.Y = screenHeight - g.ball.Radius
-		g.ball.Vel.Y = -g.ball.Vel.Y * restitution
+// resolveBallCollisions finds overlapping ball pairs via the broadphase
+// selected by UseSpatialGrid and applies an equal-mass-or-not elastic
+// impulse to each overlapping pair.
+func (g *Game) resolveBallCollisions() {
+	for _, pair := range g.broadphasePairs() {
+		g.collideBalls(pair[0], pair[1])
 	}
+}
 
-	return nil
+// collideBalls resolves a single ball-ball collision between the balls at
+// indices i and j. For overlapping circles it computes the collision
+// normal n = (p2-p1).Normalize(), and if the balls are approaching along n
+// applies the impulse j = (1+e)(vrel·n) / (1/m1 + 1/m2), then splits the
+// interpenetration along n weighted by inverse mass.
+func (g *Game) collideBalls(i, j int) {
+	a, b := &g.Balls[i], &g.Balls[j]
+
+	delta := b.Pos.Sub(a.Pos)
+	dist := delta.Length()
+	minDist := a.Radius + b.Radius
+	if dist >= minDist || dist == 0 {
+		return
+	}
+	n := delta.Mul(1 / dist)
+
+	vrel := b.Vel.Sub(a.Vel)
+	vn := vrel.Dot(n)
+	invMassA, invMassB := 1/a.Mass, 1/b.Mass
+	if vn < 0 {
+		impulseMag := (1 + restitution) * vn / (invMassA + invMassB)
+		impulse := n.Mul(impulseMag)
+		a.Vel = a.Vel.Add(impulse.Mul(invMassA))
+		b.Vel = b.Vel.Sub(impulse.Mul(invMassB))
+	}
+
+	// Split the overlap along n, weighted by inverse mass so the lighter
+	// ball gives way more.
+	penetration := minDist - dist
+	totalInvMass := invMassA + invMassB
+	a.Pos = a.Pos.Sub(n.Mul(penetration * invMassA / totalInvMass))
+	b.Pos = b.Pos.Add(n.Mul(penetration * invMassB / totalInvMass))
 }
 
-// Draw is called every frame to render the scene.
+// broadphasePairs returns the index pairs of balls that should be tested
+// for ball-ball collision this frame.
+func (g *Game) broadphasePairs() [][2]int {
+	if g.UseSpatialGrid {
+		return g.gridBroadphasePairs()
+	}
+	return g.allPairsBroadphase()
+}
+
+// allPairsBroadphase is the default O(n²) broadphase: every ball is tested
+// against every other ball.
+func (g *Game) allPairsBroadphase() [][2]int {
+	n := len(g.Balls)
+	pairs := make([][2]int, 0, n*(n-1)/2)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			pairs = append(pairs, [2]int{i, j})
+		}
+	}
+	return pairs
+}
+
+// gridCell identifies a bucket of the uniform collision grid.
+type gridCell struct{ X, Y int }
+
+// gridBroadphasePairs buckets balls into a uniform grid and only pairs up
+// balls sharing or neighboring a cell, so cost stays roughly linear in the
+// number of balls once hundreds are on screen. The cell size is widened
+// beyond gridCellSize when any ball's diameter exceeds it, so that an
+// overlapping pair (dist < r1+r2 <= 2*maxRadius <= cellSize) can never land
+// more than one cell apart and be missed by the 3x3 neighborhood scan.
+func (g *Game) gridBroadphasePairs() [][2]int {
+	cellSize := gridCellSize
+	for i := range g.Balls {
+		if d := 2 * g.Balls[i].Radius; d > cellSize {
+			cellSize = d
+		}
+	}
+
+	cellOf := func(p Vector) gridCell {
+		return gridCell{int(math.Floor(p.X / cellSize)), int(math.Floor(p.Y / cellSize))}
+	}
+
+	cells := make(map[gridCell][]int, len(g.Balls))
+	for i := range g.Balls {
+		k := cellOf(g.Balls[i].Pos)
+		cells[k] = append(cells[k], i)
+	}
+
+	seen := make(map[[2]int]bool)
+	var pairs [][2]int
+	for i := range g.Balls {
+		base := cellOf(g.Balls[i].Pos)
+		for dx := -1; dx <= 1; dx++ {
+			for dy := -1; dy <= 1; dy++ {
+				for _, j := range cells[gridCell{base.X + dx, base.Y + dy}] {
+					if j <= i {
+						continue
+					}
+					key := [2]int{i, j}
+					if !seen[key] {
+						seen[key] = true
+						pairs = append(pairs, key)
+					}
+				}
+			}
+		}
+	}
+	return pairs
+}
+
+// Draw is called every frame to render the scene. It uses the batched
+// ebiten/v2/vector primitives instead of per-pixel img.Set calls, which
+// would otherwise force a CPU readback/upload every frame and caps how
+// many balls can be drawn before the frame rate suffers.
 func (g *Game) Draw(screen *ebiten.Image) {
 	// Clear the screen.
 	screen.Fill(color.RGBA{30, 30, 30, 255})
 
-	// Draw the hexagon.
+	// Draw the polygon's boundary.
 	vertices := g.hex.Vertices()
-	for i := 0; i < 6; i++ {
+	n := len(vertices)
+	for i := 0; i < n; i++ {
 		a := vertices[i]
-		b := vertices[(i+1)%6]
-		ebitenutil.DrawLine(screen, a.X, a.Y, b.X, b.Y, color.RGBA{200, 200, 200, 255})
+		b := vertices[(i+1)%n]
+		vector.StrokeLine(screen, float32(a.X), float32(a.Y), float32(b.X), float32(b.Y), 1, color.RGBA{200, 200, 200, 255}, true)
 	}
 
-	// Draw the ball.
-	// (For simplicity, we use a custom function to draw a filled circle.)
-	drawCircle(screen, int(g.ball.Pos.X), int(g.ball.Pos.Y), int(g.ball.Radius), color.RGBA{220, 50, 50, 255})
+	// Draw each ball's fading trail, oldest first, then the ball itself.
+	for _, ball := range g.Balls {
+		for i := trailLength - 1; i >= 0; i-- {
+			pos, ok := ball.Trail.at(i)
+			if !ok {
+				continue
+			}
+			fade := 1 - float64(i)/float64(trailLength)
+			vector.DrawFilledCircle(
+				screen,
+				float32(pos.X), float32(pos.Y), float32(ball.Radius*fade),
+				color.RGBA{220, 50, 50, uint8(180 * fade)},
+				true,
+			)
+		}
+		vector.DrawFilledCircle(screen, float32(ball.Pos.X), float32(ball.Pos.Y), float32(ball.Radius), color.RGBA{220, 50, 50, 255}, true)
+	}
 }
 
 // Layout specifies the game’s internal resolution.
@@ -184,6 +667,74 @@ func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
 
 // --- Utility functions ---
 
+// edgeHit is the result of a swept circle-vs-segment test: the time of
+// first contact and the contact normal (pointing from the wall toward the
+// ball center).
+type edgeHit struct {
+	t      float64
+	normal Vector
+}
+
+// sweepCircleAgainstSegment finds the earliest t in [0, maxT] at which a
+// circle of the given radius, centered at p0 and moving at constant
+// velocity v, first touches the segment [a,c]. The segment's interior is
+// linear in t (perpendicular distance to the edge's line), while contact
+// against either endpoint is the usual quadratic swept circle-vs-point
+// test; this tries the segment interior first and falls back to whichever
+// endpoint is hit earliest.
+func sweepCircleAgainstSegment(p0, v, a, c Vector, radius, maxT float64) (edgeHit, bool) {
+	edge := c.Sub(a)
+	length := edge.Length()
+	if length == 0 {
+		return edgeHit{}, false
+	}
+	eDir := edge.Mul(1 / length)
+	inward := Vector{X: -eDir.Y, Y: eDir.X} // left-of-edge normal; points into a CCW polygon's interior
+
+	rel := p0.Sub(a)
+	d0 := rel.Dot(inward)
+	dv := v.Dot(inward)
+	if dv < 0 {
+		if t := (radius - d0) / dv; t >= 0 && t <= maxT {
+			contact := p0.Add(v.Mul(t))
+			if s := contact.Sub(a).Dot(eDir) / length; s >= 0 && s <= 1 {
+				return edgeHit{t: t, normal: inward}, true
+			}
+		}
+	}
+
+	best, ok := edgeHit{}, false
+	for _, vertex := range [2]Vector{a, c} {
+		if t, hitVertex := sweepCircleAgainstPoint(p0, v, vertex, radius, maxT); hitVertex && (!ok || t < best.t) {
+			contact := p0.Add(v.Mul(t))
+			best, ok = edgeHit{t: t, normal: contact.Sub(vertex).Normalize()}, true
+		}
+	}
+	return best, ok
+}
+
+// sweepCircleAgainstPoint solves |p0 + v*t - point| = radius for the
+// earliest t in [0, maxT], i.e. the moment a circle moving at constant
+// velocity first touches a stationary point.
+func sweepCircleAgainstPoint(p0, v, point Vector, radius, maxT float64) (float64, bool) {
+	rel := p0.Sub(point)
+	a := v.Dot(v)
+	if a == 0 {
+		return 0, false
+	}
+	b := 2 * rel.Dot(v)
+	c := rel.Dot(rel) - radius*radius
+	disc := b*b - 4*a*c
+	if disc < 0 {
+		return 0, false
+	}
+	t := (-b - math.Sqrt(disc)) / (2 * a)
+	if t < 0 || t > maxT {
+		return 0, false
+	}
+	return t, true
+}
+
 // closestPointOnSegment returns the closest point on the line segment AB to point P.
 func closestPointOnSegment(a, b, p Vector) Vector {
 	ab := b.Sub(a)
@@ -197,37 +748,24 @@ func closestPointOnSegment(a, b, p Vector) Vector {
 	return a.Add(ab.Mul(t))
 }
 
-// drawCircle draws a filled circle on the given image using a simple algorithm.
-func drawCircle(img *ebiten.Image, cx, cy, r int, clr color.Color) {
-	// A simple approach: for each y offset in [-r, r], compute the horizontal span.
-	for y := -r; y <= r; y++ {
-		// xSpan is based on circle equation: x^2 + y^2 <= r^2.
-		xSpan := int(math.Sqrt(float64(r*r - y*y)))
-		for x := -xSpan; x <= xSpan; x++ {
-			img.Set(cx+x, cy+y, clr)
-		}
-	}
-}
-
 // --- Main function ---
 func main() {
 	ebiten.SetWindowSize(screenWidth, screenHeight)
-	ebiten.SetWindowTitle("Bouncing Ball in a Spinning Hexagon")
+	ebiten.SetWindowTitle("Bouncing Balls in a Spinning Hexagon")
+
+	hex := NewRegularPolygon(6, hexagonRadius)
+	hex.Center = Vector{X: screenWidth / 2, Y: screenHeight / 2}
+	hex.Angle = initialHexagonAngle
+	hex.AngularVelocity = hexagonAngularVel
 
 	game := &Game{
-		ball: Ball{
-			// Start at the center with an initial velocity.
-			Pos:    Vector{X: screenWidth / 2, Y: screenHeight / 2},
-			Vel:    Vector{X: 200, Y: -150},
-			Radius: ballRadius,
-		},
-		hex: Hexagon{
-			Center:          Vector{X: screenWidth / 2, Y: screenHeight / 2},
-			Radius:          hexagonRadius,
-			Angle:           initialHexagonAngle,
-			AngularVelocity: hexagonAngularVel,
-		},
+		hex:       *hex,
+		Input:     EbitenInput{},
+		Gravity:   gravity,
+		PhysicsHz: defaultPhysicsHz,
 	}
+	// Start with a single ball at the center with an initial velocity.
+	game.AddBall(Vector{X: screenWidth / 2, Y: screenHeight / 2}, Vector{X: 200, Y: -150}, ballRadius)
 
 	// Run the Ebiten game loop.
 	if err := ebiten.RunGame(game); err != nil {
@@ -0,0 +1,96 @@
+package main
+
+import "testing"
+
+// newTestGame builds a Game matching main()'s setup but without wiring up
+// EbitenInput, so tests can drive it deterministically via RecordedInput.
+func newTestGame() *Game {
+	hex := NewRegularPolygon(6, hexagonRadius)
+	hex.Center = Vector{X: screenWidth / 2, Y: screenHeight / 2}
+	hex.AngularVelocity = hexagonAngularVel
+
+	g := &Game{
+		hex:       *hex,
+		Gravity:   gravity,
+		PhysicsHz: defaultPhysicsHz,
+	}
+	g.AddBall(g.hex.Center, Vector{}, ballRadius)
+	return g
+}
+
+func TestRecordedInputAdvance(t *testing.T) {
+	r := &RecordedInput{Frames: []InputFrame{{Left: true}, {Right: true, Space: true}}}
+
+	if !r.LeftPressed() || r.RightPressed() {
+		t.Fatalf("frame 0: LeftPressed() = %v, RightPressed() = %v, want true, false", r.LeftPressed(), r.RightPressed())
+	}
+	r.Advance()
+
+	if !r.RightPressed() || !r.SpacePressed() {
+		t.Fatalf("frame 1: RightPressed() = %v, SpacePressed() = %v, want true, true", r.RightPressed(), r.SpacePressed())
+	}
+	r.Advance()
+
+	if r.LeftPressed() || r.RightPressed() || r.SpacePressed() {
+		t.Errorf("past the end of Frames, expected no keys pressed")
+	}
+}
+
+func TestGameUpdateWithRecordedInput(t *testing.T) {
+	tests := []struct {
+		name   string
+		frames []InputFrame
+		check  func(t *testing.T, g *Game, initialAngularVelocity float64)
+	}{
+		{
+			name:   "left arrow slows the hexagon's rotation",
+			frames: []InputFrame{{Left: true}},
+			check: func(t *testing.T, g *Game, initialAngularVelocity float64) {
+				if g.hex.AngularVelocity >= initialAngularVelocity {
+					t.Errorf("AngularVelocity = %v, want less than %v", g.hex.AngularVelocity, initialAngularVelocity)
+				}
+			},
+		},
+		{
+			name:   "right arrow speeds up the hexagon's rotation",
+			frames: []InputFrame{{Right: true}},
+			check: func(t *testing.T, g *Game, initialAngularVelocity float64) {
+				if g.hex.AngularVelocity <= initialAngularVelocity {
+					t.Errorf("AngularVelocity = %v, want greater than %v", g.hex.AngularVelocity, initialAngularVelocity)
+				}
+			},
+		},
+		{
+			name:   "space launches the first ball up and to the right",
+			frames: []InputFrame{{Space: true}},
+			check: func(t *testing.T, g *Game, _ float64) {
+				if vel := g.Balls[0].Vel; vel.X <= 0 || vel.Y >= 0 {
+					t.Errorf("Balls[0].Vel after launch = %+v, want positive X and negative Y", vel)
+				}
+			},
+		},
+		{
+			name:   "no keys pressed leaves the hexagon's rotation unchanged",
+			frames: []InputFrame{{}},
+			check: func(t *testing.T, g *Game, initialAngularVelocity float64) {
+				if g.hex.AngularVelocity != initialAngularVelocity {
+					t.Errorf("AngularVelocity = %v, want unchanged %v", g.hex.AngularVelocity, initialAngularVelocity)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame()
+			g.Input = &RecordedInput{Frames: tt.frames}
+			initialAngularVelocity := g.hex.AngularVelocity
+
+			if err := g.Update(); err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+
+			tt.check(t, g, initialAngularVelocity)
+		})
+	}
+}
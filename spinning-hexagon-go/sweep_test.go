@@ -0,0 +1,116 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+const sweepEpsilon = 1e-6
+
+// TestSweepCircleAgainstSegmentGrazing drives a ball past edge a-c (whose
+// "inward" normal, by convention, points toward +Y) at a shallow,
+// near-parallel angle from the interior side, hitting the segment's
+// interior rather than either endpoint.
+func TestSweepCircleAgainstSegmentGrazing(t *testing.T) {
+	a := Vector{X: 0, Y: 0}
+	c := Vector{X: 100, Y: 0}
+	p0 := Vector{X: 10, Y: 20}
+	v := Vector{X: 90, Y: -18} // mostly-horizontal graze, just enough Y to touch the edge
+
+	hit, ok := sweepCircleAgainstSegment(p0, v, a, c, 5, 10)
+	if !ok {
+		t.Fatalf("sweepCircleAgainstSegment() = _, false, want a hit")
+	}
+
+	contact := p0.Add(v.Mul(hit.t))
+	if contact.X <= a.X || contact.X >= c.X {
+		t.Errorf("contact.X = %v, want strictly within the segment's span (%v, %v), not an endpoint hit", contact.X, a.X, c.X)
+	}
+	if dist := math.Abs(contact.Y - a.Y); math.Abs(dist-5) > sweepEpsilon {
+		t.Errorf("contact is %v from the edge's line, want radius 5", dist)
+	}
+	if hit.normal.Y <= 0 {
+		t.Errorf("normal = %+v, want it pointing back into the interior (+Y)", hit.normal)
+	}
+}
+
+// TestSweepCircleAgainstSegmentVertex drives a ball toward vertex a from
+// beyond the segment's span, which must fall back to the point-sweep case
+// rather than reporting a segment-interior hit.
+func TestSweepCircleAgainstSegmentVertex(t *testing.T) {
+	a := Vector{X: 0, Y: 0}
+	c := Vector{X: 100, Y: 0}
+	p0 := Vector{X: -20, Y: 20}
+	v := Vector{X: 20, Y: -20} // heads straight at vertex a, off the start of the segment's span
+
+	hit, ok := sweepCircleAgainstSegment(p0, v, a, c, 5, 10)
+	if !ok {
+		t.Fatalf("sweepCircleAgainstSegment() = _, false, want a hit")
+	}
+
+	contact := p0.Add(v.Mul(hit.t))
+	if dist := contact.Sub(a).Length(); math.Abs(dist-5) > sweepEpsilon {
+		t.Errorf("contact is %v from vertex a, want radius 5", dist)
+	}
+	wantNormal := contact.Sub(a).Normalize()
+	if math.Abs(hit.normal.X-wantNormal.X) > sweepEpsilon || math.Abs(hit.normal.Y-wantNormal.Y) > sweepEpsilon {
+		t.Errorf("normal = %+v, want %+v (pointing from the vertex to the contact point)", hit.normal, wantNormal)
+	}
+}
+
+// TestSweepCircleAgainstSegmentConcavePolygon sweeps a fast ball straight
+// through the reflex notch of an L-shaped concave polygon and checks it's
+// stopped by the true boundary edge it's heading into, not tunneling past it.
+func TestSweepCircleAgainstSegmentConcavePolygon(t *testing.T) {
+	// An L-shape (CCW), with a reflex vertex at (50,50).
+	verts := []Vector{
+		{X: 0, Y: 0}, {X: 100, Y: 0}, {X: 100, Y: 50},
+		{X: 50, Y: 50}, {X: 50, Y: 100}, {X: 0, Y: 100},
+	}
+	p0 := Vector{X: 25, Y: 90}
+	v := Vector{X: 0, Y: 200} // fast enough to tunnel through the y=100 edge in one step without CCD
+
+	n := len(verts)
+	bestT := 1.0
+	hitAny := false
+	for i := 0; i < n; i++ {
+		a, c := verts[i], verts[(i+1)%n]
+		if hit, ok := sweepCircleAgainstSegment(p0, v, a, c, 5, bestT); ok {
+			bestT = hit.t
+			hitAny = true
+		}
+	}
+	if !hitAny {
+		t.Fatalf("expected the sweep to hit the bottom edge of the L-shape, got no hit")
+	}
+	if bestT <= 0 || bestT >= 1 {
+		t.Errorf("bestT = %v, want strictly within (0, 1): the ball should stop at the boundary, not pass through or stay put", bestT)
+	}
+}
+
+// TestIntegrateBallMaxCollisionsPerSubstepCap aims an extremely fast ball
+// straight at a hexagon vertex, so CCD keeps re-hitting an edge near the
+// corner every iteration. integrateBall's loop is hard-bounded at
+// maxCollisionsPerSubstep, so it must fall back to the discrete push-out and
+// return a finite position/velocity rather than wedging indefinitely.
+func TestIntegrateBallMaxCollisionsPerSubstepCap(t *testing.T) {
+	hex := NewRegularPolygon(6, hexagonRadius)
+	g := &Game{hex: *hex, PhysicsHz: defaultPhysicsHz}
+	vertices := g.hex.Vertices()
+
+	dir := vertices[0].Sub(g.hex.Center).Normalize()
+	b := &Ball{
+		Pos:    g.hex.Center,
+		Vel:    dir.Mul(1e6), // fast enough to trigger a CCD hit on every iteration
+		Radius: ballRadius,
+	}
+
+	g.integrateBall(b, vertices, dt)
+
+	if math.IsNaN(b.Pos.X) || math.IsNaN(b.Pos.Y) || math.IsInf(b.Pos.X, 0) || math.IsInf(b.Pos.Y, 0) {
+		t.Errorf("Pos = %+v after integrateBall, want finite", b.Pos)
+	}
+	if math.IsNaN(b.Vel.X) || math.IsNaN(b.Vel.Y) || math.IsInf(b.Vel.X, 0) || math.IsInf(b.Vel.Y, 0) {
+		t.Errorf("Vel = %+v after integrateBall, want finite", b.Vel)
+	}
+}
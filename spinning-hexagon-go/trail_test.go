@@ -0,0 +1,80 @@
+package main
+
+import "testing"
+
+// TestTrailPushAt covers the ring buffer across its three regimes:
+// partially filled, freshly full, and wrapped past capacity.
+func TestTrailPushAt(t *testing.T) {
+	tests := []struct {
+		name       string
+		pushes     int // number of positions pushed, as Vector{X: i, Y: 0} for i in [0, pushes)
+		checkIndex int // the "at" index being checked
+		wantX      float64
+		wantOK     bool
+	}{
+		{
+			name:       "not yet full: at(0) is the only push",
+			pushes:     1,
+			checkIndex: 0,
+			wantX:      0,
+			wantOK:     true,
+		},
+		{
+			name:       "not yet full: at(i) past count reports not ok",
+			pushes:     3,
+			checkIndex: 3,
+			wantOK:     false,
+		},
+		{
+			name:       "freshly full: at(0) is the newest push",
+			pushes:     trailLength,
+			checkIndex: 0,
+			wantX:      float64(trailLength - 1),
+			wantOK:     true,
+		},
+		{
+			name:       "freshly full: at(trailLength-1) is the oldest push",
+			pushes:     trailLength,
+			checkIndex: trailLength - 1,
+			wantX:      0,
+			wantOK:     true,
+		},
+		{
+			name:       "wrapped past capacity: at(0) is still the newest push",
+			pushes:     trailLength + 3,
+			checkIndex: 0,
+			wantX:      float64(trailLength + 2),
+			wantOK:     true,
+		},
+		{
+			name:       "wrapped past capacity: at(trailLength-1) is the oldest surviving push",
+			pushes:     trailLength + 3,
+			checkIndex: trailLength - 1,
+			wantX:      3, // pushes 0,1,2 were evicted
+			wantOK:     true,
+		},
+		{
+			name:       "wrapped past capacity: at(trailLength) is past count",
+			pushes:     trailLength + 3,
+			checkIndex: trailLength,
+			wantOK:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tr trail
+			for i := 0; i < tt.pushes; i++ {
+				tr.push(Vector{X: float64(i), Y: 0})
+			}
+
+			pos, ok := tr.at(tt.checkIndex)
+			if ok != tt.wantOK {
+				t.Fatalf("at(%d) ok = %v, want %v", tt.checkIndex, ok, tt.wantOK)
+			}
+			if ok && pos.X != tt.wantX {
+				t.Errorf("at(%d).X = %v, want %v", tt.checkIndex, pos.X, tt.wantX)
+			}
+		})
+	}
+}